@@ -0,0 +1,74 @@
+package medtronic
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	setSquareBolus Command = 0x89
+	setDualBolus   Command = 0x8A
+)
+
+// SetSquareBolus delivers a square-wave bolus of amount spread evenly
+// over duration.
+func (pump *Pump) SetSquareBolus(amount Insulin, duration time.Duration) {
+	d := pump.halfHours(duration)
+	if pump.Error() != nil {
+		return
+	}
+	maxBolus := pump.Settings().MaxBolus
+	if pump.Error() != nil {
+		return
+	}
+	strokes, ok := pump.checkBolusAmount(amount, maxBolus)
+	if !ok {
+		return
+	}
+	r := marshalUint16(strokes)
+	pump.Execute(setSquareBolus, r[0], r[1], d)
+}
+
+// SetDualBolus delivers a dual-wave bolus: normal is delivered
+// immediately and square is spread evenly over duration.
+func (pump *Pump) SetDualBolus(normal, square Insulin, duration time.Duration) {
+	d := pump.halfHours(duration)
+	if pump.Error() != nil {
+		return
+	}
+	maxBolus := pump.Settings().MaxBolus
+	if pump.Error() != nil {
+		return
+	}
+	normalStrokes, ok := pump.checkBolusAmount(normal, maxBolus)
+	if !ok {
+		return
+	}
+	squareStrokes, ok := pump.checkBolusAmount(square, maxBolus)
+	if !ok {
+		return
+	}
+	n := marshalUint16(normalStrokes)
+	s := marshalUint16(squareStrokes)
+	pump.Execute(setDualBolus, n[0], n[1], s[0], s[1], d)
+}
+
+// checkBolusAmount validates amount against maxBolus and rounds it
+// down to a whole number of 25-milliUnit strokes.
+func (pump *Pump) checkBolusAmount(amount Insulin, maxBolus MilliUnits) (uint16, bool) {
+	if amount < 0 {
+		pump.SetError(fmt.Errorf("bolus amount (%v) is negative", amount))
+		return 0, false
+	}
+	if amount > Insulin(maxBolus) {
+		pump.SetError(fmt.Errorf("bolus amount (%v) is larger than maximum bolus (%v)", amount, maxBolus))
+		return 0, false
+	}
+	strokes := amount / 25
+	actual := strokes * 25
+	if actual != amount {
+		log.Printf("rounding bolus amount from %v to %v", amount, actual)
+	}
+	return uint16(strokes), true
+}
@@ -152,6 +152,23 @@ const (
 
 // Download requests the given history page from the pump.
 func (pump *Pump) Download(cmd Command, page int) []byte {
+	return pump.DownloadWithProgress(cmd, page, nil)
+}
+
+// DownloadObserver is notified after each fragment of a history page
+// is received, and once more when the page has been reassembled and
+// its CRC-16 verified.
+//
+// seq is the fragment's sequence number (1 to numFragments); done is
+// true only for the final call, at which point payload holds the
+// complete page with its trailing CRC-16 removed rather than a single
+// fragment. remaining is the number of page bytes not yet received.
+type DownloadObserver func(seq int, done bool, remaining int, payload []byte)
+
+// DownloadWithProgress behaves like Download, but invokes observer
+// as each fragment arrives so that callers can report progress on
+// long-running downloads. observer may be nil.
+func (pump *Pump) DownloadWithProgress(cmd Command, page int, observer DownloadObserver) []byte {
 	timeout := pump.Timeout()
 	pump.SetTimeout(downloadTimeout)
 	defer pump.SetTimeout(timeout)
@@ -171,10 +188,20 @@ func (pump *Pump) Download(cmd Command, page int) []byte {
 		}
 		if n == seq {
 			results = append(results, payload...)
+			if observer != nil {
+				observer(n, false, historyPageSize-len(results), payload)
+			}
 			seq++
 		}
 		if n == numFragments {
-			return pump.checkPageCRC(page, results)
+			verified := pump.checkPageCRC(page, results)
+			if pump.Error() != nil {
+				return nil
+			}
+			if observer != nil {
+				observer(numFragments, true, 0, verified)
+			}
+			return verified
 		}
 		// Acknowledge the current fragment and receive the next.
 		next := pump.perform(ack, cmd, nil)
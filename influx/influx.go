@@ -0,0 +1,128 @@
+// Package influx converts pump history records and settings into
+// InfluxDB line-protocol points, suitable for ingestion by Telegraf
+// or a Telegraf-compatible InfluxDB listener.
+package influx
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ecc1/medtronic"
+)
+
+// WritePoints writes one InfluxDB line-protocol point per
+// convertible history record in records to w, tagging each point
+// with the given pump ID. History records must be in chronological
+// order.
+func WritePoints(w io.Writer, pumpID string, records medtronic.History) error {
+	for i, r := range records {
+		t := time.Time(r.Time)
+		var line string
+		switch r.Type() {
+		case medtronic.Bolus:
+			line = BolusRecord(pumpID, t, r.Info.(medtronic.BolusRecord))
+		case medtronic.BGCapture:
+			line = GlucoseRecord(pumpID, t, r.Info.(medtronic.GlucoseRecord))
+		case medtronic.TempBasalRate:
+			var r2 *medtronic.HistoryRecord
+			if i+1 < len(records) {
+				r2 = &records[i+1]
+			}
+			if r2 == nil || r2.Type() != medtronic.TempBasalDuration {
+				continue
+			}
+			duration := time.Duration(r2.Info.(medtronic.Duration))
+			line = TempBasalRecord(pumpID, t, r.Info.(medtronic.TempBasalRecord), duration)
+		default:
+			continue
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BolusRecord renders a bolus history record as an InfluxDB
+// line-protocol point in the pump_bolus measurement.
+func BolusRecord(pumpID string, t time.Time, b medtronic.BolusRecord) string {
+	return fmt.Sprintf(
+		"pump_bolus,pump=%s amount=%s,duration=%di %d\n",
+		escapeTag(pumpID),
+		milliUnitsField(int64(b.Amount)),
+		int64(time.Duration(b.Duration)/time.Second),
+		t.UnixNano(),
+	)
+}
+
+// TempBasalRecord renders a temporary basal rate history record,
+// together with its duration from the following TempBasalDuration
+// record, as an InfluxDB line-protocol point in the pump_temp_basal
+// measurement.
+func TempBasalRecord(pumpID string, t time.Time, tb medtronic.TempBasalRecord, duration time.Duration) string {
+	typeTag := "Absolute"
+	var field string
+	switch tb.Type {
+	case medtronic.Percent:
+		typeTag = "Percent"
+		field = fmt.Sprintf("percent=%di", tb.Value.(uint8))
+	default:
+		field = fmt.Sprintf("rate=%s", milliUnitsField(int64(tb.Value.(medtronic.Insulin))))
+	}
+	return fmt.Sprintf(
+		"pump_temp_basal,pump=%s,type=%s %s,duration=%di %d\n",
+		escapeTag(pumpID), typeTag, field, int64(duration/time.Second), t.UnixNano(),
+	)
+}
+
+// GlucoseRecord renders a glucose history record as an InfluxDB
+// line-protocol point in the pump_glucose measurement.
+func GlucoseRecord(pumpID string, t time.Time, g medtronic.GlucoseRecord) string {
+	return fmt.Sprintf(
+		"pump_glucose,pump=%s,units=%s value=%di %d\n",
+		escapeTag(pumpID), g.Units, int(g.Glucose), t.UnixNano(),
+	)
+}
+
+// Voltage renders a battery voltage reading as an InfluxDB
+// line-protocol point in the pump_battery measurement.
+func Voltage(pumpID string, t time.Time, v medtronic.Voltage) string {
+	return fmt.Sprintf(
+		"pump_battery,pump=%s voltage=%s %d\n",
+		escapeTag(pumpID), milliUnitsField(int64(v)), t.UnixNano(),
+	)
+}
+
+// SettingsInfo renders a pump's current settings as an InfluxDB
+// line-protocol point in the pump_settings measurement. It is
+// intended to be written periodically as a snapshot, so that
+// downstream time-series tooling can graph AutoOff, MaxBolus,
+// MaxBasal, InsulinAction, and RfEnabled over time.
+func SettingsInfo(pumpID string, t time.Time, info medtronic.SettingsInfo) string {
+	return fmt.Sprintf(
+		"pump_settings,pump=%s auto_off=%di,max_bolus=%s,max_basal=%s,insulin_action=%di,rf_enabled=%t %d\n",
+		escapeTag(pumpID),
+		int64(info.AutoOff/time.Hour),
+		milliUnitsField(int64(info.MaxBolus)),
+		milliUnitsField(int64(info.MaxBasal)),
+		int64(info.InsulinAction/time.Hour),
+		info.RfEnabled,
+		t.UnixNano(),
+	)
+}
+
+// milliUnitsField formats a milliUnits quantity (insulin or voltage)
+// as a line-protocol float field value.
+func milliUnitsField(milliUnits int64) string {
+	return strconv.FormatFloat(float64(milliUnits)/1000, 'f', -1, 64)
+}
+
+// escapeTag escapes the characters that InfluxDB line protocol
+// treats specially in a tag key or value.
+func escapeTag(s string) string {
+	r := strings.NewReplacer(",", "\\,", "=", "\\=", " ", "\\ ")
+	return r.Replace(s)
+}
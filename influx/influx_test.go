@@ -0,0 +1,109 @@
+package influx
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ecc1/medtronic"
+)
+
+func TestEscapeTag(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"cgm1", "cgm1"},
+		{"office,kitchen", "office\\,kitchen"},
+		{"a=b", "a\\=b"},
+		{"my pump", "my\\ pump"},
+		{"a,b=c d", "a\\,b\\=c\\ d"},
+	}
+	for _, test := range tests {
+		if got := escapeTag(test.in); got != test.want {
+			t.Errorf("escapeTag(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestMilliUnitsField(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0"},
+		{1000, "1"},
+		{1500, "1.5"},
+		{-250, "-0.25"},
+	}
+	for _, test := range tests {
+		if got := milliUnitsField(test.in); got != test.want {
+			t.Errorf("milliUnitsField(%d) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestBolusRecord(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	b := medtronic.BolusRecord{Amount: medtronic.Insulin(1500), Duration: medtronic.Duration(30 * time.Minute)}
+	line := BolusRecord("pump,1", ts, b)
+	want := "pump_bolus,pump=pump\\,1 amount=1.5,duration=1800i " + strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("BolusRecord() = %q, want %q", line, want)
+	}
+}
+
+func TestTempBasalRecordPercent(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	tb := medtronic.TempBasalRecord{Type: medtronic.Percent, Value: uint8(50)}
+	line := TempBasalRecord("pump1", ts, tb, 30*time.Minute)
+	want := "pump_temp_basal,pump=pump1,type=Percent percent=50i,duration=1800i " + strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("TempBasalRecord() = %q, want %q", line, want)
+	}
+}
+
+func TestTempBasalRecordAbsolute(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	tb := medtronic.TempBasalRecord{Type: medtronic.Absolute, Value: medtronic.Insulin(750)}
+	line := TempBasalRecord("pump1", ts, tb, time.Hour)
+	want := "pump_temp_basal,pump=pump1,type=Absolute rate=0.75,duration=3600i " + strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("TempBasalRecord() = %q, want %q", line, want)
+	}
+}
+
+func TestGlucoseRecord(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	g := medtronic.GlucoseRecord{Glucose: 120, Units: "mg/dL"}
+	line := GlucoseRecord("pump1", ts, g)
+	want := "pump_glucose,pump=pump1,units=mg/dL value=120i " + strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("GlucoseRecord() = %q, want %q", line, want)
+	}
+}
+
+func TestVoltage(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	line := Voltage("pump1", ts, medtronic.Voltage(1550))
+	want := "pump_battery,pump=pump1 voltage=1.55 " + strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("Voltage() = %q, want %q", line, want)
+	}
+}
+
+func TestSettingsInfo(t *testing.T) {
+	ts := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	info := medtronic.SettingsInfo{
+		AutoOff:       7 * time.Hour,
+		MaxBolus:      25000,
+		MaxBasal:      2000,
+		InsulinAction: 4 * time.Hour,
+		RfEnabled:     true,
+	}
+	line := SettingsInfo("pump1", ts, info)
+	want := "pump_settings,pump=pump1 auto_off=7i,max_bolus=25,max_basal=2,insulin_action=4i,rf_enabled=true " +
+		strconv.FormatInt(ts.UnixNano(), 10) + "\n"
+	if line != want {
+		t.Errorf("SettingsInfo() = %q, want %q", line, want)
+	}
+}
@@ -0,0 +1,3 @@
+package medtronicpb
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. medtronic.proto
@@ -0,0 +1,142 @@
+// Package medtronicpb implements a gRPC PumpService that wraps a
+// medtronic.Pump, so that a headless pump gateway can be driven by
+// remote clients such as web dashboards.
+package medtronicpb
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ecc1/medtronic"
+)
+
+// Server implements PumpServiceServer on top of a medtronic.Pump.
+type Server struct {
+	UnimplementedPumpServiceServer
+	pump *medtronic.Pump
+}
+
+// NewServer returns a Server that executes commands against pump.
+func NewServer(pump *medtronic.Pump) *Server {
+	return &Server{pump: pump}
+}
+
+// pumpError returns a gRPC status error for the pump's current error,
+// or nil if there is none.
+func (s *Server) pumpError() error {
+	err := s.pump.Error()
+	if err == nil {
+		return nil
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// Execute implements PumpServiceServer.
+func (s *Server) Execute(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
+	data := s.pump.Execute(medtronic.Command(req.Command), req.Params...)
+	if err := s.pumpError(); err != nil {
+		return nil, err
+	}
+	return &ExecuteResponse{Data: data}, nil
+}
+
+// TempBasal implements PumpServiceServer.
+func (s *Server) TempBasal(ctx context.Context, req *TempBasalRequest) (*TempBasalResponse, error) {
+	info := s.pump.TempBasal()
+	if err := s.pumpError(); err != nil {
+		return nil, err
+	}
+	return tempBasalResponse(info), nil
+}
+
+// tempBasalResponse converts a medtronic.TempBasalInfo into the wire
+// representation, choosing the RateOrPercent oneof branch by Type.
+func tempBasalResponse(info medtronic.TempBasalInfo) *TempBasalResponse {
+	resp := &TempBasalResponse{
+		DurationSeconds: int64(info.Duration / time.Second),
+	}
+	switch info.Type {
+	case medtronic.Absolute:
+		resp.Type = TempBasalType_ABSOLUTE
+		if info.Rate != nil {
+			resp.RateOrPercent = &TempBasalResponse_RateMilliUnits{RateMilliUnits: int64(*info.Rate)}
+		}
+	case medtronic.Percent:
+		resp.Type = TempBasalType_PERCENT
+		if info.Percent != nil {
+			resp.RateOrPercent = &TempBasalResponse_Percent{Percent: uint32(*info.Percent)}
+		}
+	}
+	return resp
+}
+
+// SetAbsoluteTempBasal implements PumpServiceServer.
+func (s *Server) SetAbsoluteTempBasal(ctx context.Context, req *SetAbsoluteTempBasalRequest) (*SetAbsoluteTempBasalResponse, error) {
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	rate := medtronic.Insulin(req.RateMilliUnits)
+	s.pump.SetAbsoluteTempBasal(duration, rate)
+	if err := s.pumpError(); err != nil {
+		return nil, err
+	}
+	return &SetAbsoluteTempBasalResponse{}, nil
+}
+
+// Settings implements PumpServiceServer.
+func (s *Server) Settings(ctx context.Context, req *SettingsRequest) (*SettingsResponse, error) {
+	info := s.pump.Settings()
+	if err := s.pumpError(); err != nil {
+		return nil, err
+	}
+	return settingsResponse(info), nil
+}
+
+// settingsResponse converts a medtronic.SettingsInfo into the wire
+// representation.
+func settingsResponse(info medtronic.SettingsInfo) *SettingsResponse {
+	return &SettingsResponse{
+		AutoOffSeconds:       int64(info.AutoOff / time.Second),
+		InsulinActionSeconds: int64(info.InsulinAction / time.Second),
+		InsulinConcentration: int32(info.InsulinConcentration),
+		MaxBolusMilliUnits:   int64(info.MaxBolus),
+		MaxBasalMilliUnits:   int64(info.MaxBasal),
+		RfEnabled:            info.RfEnabled,
+		SelectedPattern:      int32(info.SelectedPattern),
+	}
+}
+
+// HistoryPage implements PumpServiceServer. It streams a HistoryChunk
+// for each fragment as medtronic.Pump.Download receives it, and a
+// final HistoryChunk carrying the reassembled, CRC-verified page.
+func (s *Server) HistoryPage(req *HistoryPageRequest, stream PumpService_HistoryPageServer) error {
+	var sendErr error
+	s.pump.DownloadWithProgress(medtronic.Command(req.Command), int(req.Page), func(seq int, done bool, remaining int, payload []byte) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(historyChunk(seq, done, remaining, payload))
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return s.pumpError()
+}
+
+// historyChunk builds the HistoryChunk for one DownloadObserver
+// callback: every fragment but the last carries its payload in
+// Fragment, and the last carries the reassembled page in Page.
+func historyChunk(seq int, done bool, remaining int, payload []byte) *HistoryChunk {
+	chunk := &HistoryChunk{
+		Sequence:       int32(seq),
+		RemainingBytes: int32(remaining),
+		Done:           done,
+	}
+	if done {
+		chunk.Page = payload
+	} else {
+		chunk.Fragment = payload
+	}
+	return chunk
+}
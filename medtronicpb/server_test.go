@@ -0,0 +1,103 @@
+package medtronicpb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ecc1/medtronic"
+)
+
+func TestTempBasalResponse(t *testing.T) {
+	rate := medtronic.Insulin(750)
+	percent := uint8(50)
+	tests := []struct {
+		name string
+		info medtronic.TempBasalInfo
+		want *TempBasalResponse
+	}{
+		{
+			name: "absolute",
+			info: medtronic.TempBasalInfo{Duration: 30 * time.Minute, Type: medtronic.Absolute, Rate: &rate},
+			want: &TempBasalResponse{
+				DurationSeconds: 1800,
+				Type:            TempBasalType_ABSOLUTE,
+				RateOrPercent:   &TempBasalResponse_RateMilliUnits{RateMilliUnits: 750},
+			},
+		},
+		{
+			name: "percent",
+			info: medtronic.TempBasalInfo{Duration: time.Hour, Type: medtronic.Percent, Percent: &percent},
+			want: &TempBasalResponse{
+				DurationSeconds: 3600,
+				Type:            TempBasalType_PERCENT,
+				RateOrPercent:   &TempBasalResponse_Percent{Percent: 50},
+			},
+		},
+		{
+			name: "absolute with no rate in effect",
+			info: medtronic.TempBasalInfo{Duration: 0, Type: medtronic.Absolute},
+			want: &TempBasalResponse{
+				DurationSeconds: 0,
+				Type:            TempBasalType_ABSOLUTE,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := tempBasalResponse(test.info)
+			if got.DurationSeconds != test.want.DurationSeconds || got.Type != test.want.Type {
+				t.Fatalf("tempBasalResponse(%+v) = %+v, want %+v", test.info, got, test.want)
+			}
+			if got.GetRateMilliUnits() != test.want.GetRateMilliUnits() {
+				t.Errorf("RateMilliUnits = %d, want %d", got.GetRateMilliUnits(), test.want.GetRateMilliUnits())
+			}
+			if got.GetPercent() != test.want.GetPercent() {
+				t.Errorf("Percent = %d, want %d", got.GetPercent(), test.want.GetPercent())
+			}
+		})
+	}
+}
+
+func TestSettingsResponse(t *testing.T) {
+	info := medtronic.SettingsInfo{
+		AutoOff:              7 * time.Hour,
+		InsulinAction:        4 * time.Hour,
+		InsulinConcentration: 50,
+		MaxBolus:             25000,
+		MaxBasal:             2000,
+		RfEnabled:            true,
+		SelectedPattern:      2,
+	}
+	want := &SettingsResponse{
+		AutoOffSeconds:       7 * 3600,
+		InsulinActionSeconds: 4 * 3600,
+		InsulinConcentration: 50,
+		MaxBolusMilliUnits:   25000,
+		MaxBasalMilliUnits:   2000,
+		RfEnabled:            true,
+		SelectedPattern:      2,
+	}
+	got := settingsResponse(info)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("settingsResponse(%+v) = %+v, want %+v", info, got, want)
+	}
+}
+
+func TestHistoryChunk(t *testing.T) {
+	fragment := historyChunk(3, false, 512, []byte{1, 2, 3})
+	if fragment.Sequence != 3 || fragment.RemainingBytes != 512 || fragment.Done {
+		t.Errorf("fragment chunk = %+v, want Sequence=3 RemainingBytes=512 Done=false", fragment)
+	}
+	if len(fragment.Fragment) != 3 || fragment.Page != nil {
+		t.Errorf("fragment chunk should carry its payload in Fragment, not Page: %+v", fragment)
+	}
+
+	page := historyChunk(4, true, 0, []byte{4, 5, 6, 7})
+	if !page.Done {
+		t.Errorf("final chunk should have Done set")
+	}
+	if len(page.Page) != 4 || page.Fragment != nil {
+		t.Errorf("final chunk should carry its payload in Page, not Fragment: %+v", page)
+	}
+}
@@ -52,11 +52,7 @@ func getRecordInfo(r HistoryRecord, r2 *HistoryRecord, info *nightscout.Treatmen
 	case TempBasalRate:
 		return tempBasalInfo(r, r2, info)
 	case Bolus:
-		b := r.Info.(BolusRecord)
-		ins := b.Amount.NightscoutInsulin()
-		info.Insulin = &ins
-		min := int(b.Duration / Duration(time.Minute))
-		info.Duration = &min
+		bolusInfo(r.Info.(BolusRecord), info)
 	case Rewind:
 		if !nextEvent(r, r2, Prime) {
 			return false
@@ -77,6 +73,29 @@ func getRecordInfo(r HistoryRecord, r2 *HistoryRecord, info *nightscout.Treatmen
 	return true
 }
 
+// bolusInfo fills in info for a bolus history record. A non-zero
+// Duration identifies the square-wave (extended) component of a
+// square or dual-wave bolus; history does not retain the normal/square
+// split for dual-wave boluses, so it is reported as entirely
+// extended, matching how AndroidAPS/Loop expect extended boluses to
+// appear.
+func bolusInfo(b BolusRecord, info *nightscout.Treatment) {
+	ins := b.Amount.NightscoutInsulin()
+	info.Insulin = &ins
+	min := int(b.Duration / Duration(time.Minute))
+	info.Duration = &min
+	if b.Duration == 0 {
+		return
+	}
+	info.EventType = "Combo Bolus"
+	splitNow, splitExt := 0, 100
+	info.SplitNow = &splitNow
+	info.SplitExt = &splitExt
+	hours := float64(b.Duration/Duration(time.Minute)) / 60
+	relative := float64(ins) / hours
+	info.Relative = &relative
+}
+
 func tempBasalInfo(r HistoryRecord, r2 *HistoryRecord, info *nightscout.Treatment) bool {
 	tb := r.Info.(TempBasalRecord)
 	if tb.Type != Absolute {
@@ -0,0 +1,66 @@
+package medtronic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecc1/nightscout"
+)
+
+func TestBolusInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		record       BolusRecord
+		wantType     string
+		wantDuration int
+	}{
+		{
+			name:         "plain meal bolus",
+			record:       BolusRecord{Amount: Insulin(1500), Duration: 0},
+			wantType:     "Meal Bolus",
+			wantDuration: 0,
+		},
+		{
+			name:         "square/dual-wave bolus",
+			record:       BolusRecord{Amount: Insulin(2000), Duration: Duration(30 * time.Minute)},
+			wantType:     "Combo Bolus",
+			wantDuration: 30,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := &nightscout.Treatment{EventType: "Meal Bolus"}
+			bolusInfo(test.record, info)
+
+			if info.EventType != test.wantType {
+				t.Errorf("EventType = %q, want %q", info.EventType, test.wantType)
+			}
+			if info.Duration == nil || *info.Duration != test.wantDuration {
+				t.Errorf("Duration = %v, want %d", info.Duration, test.wantDuration)
+			}
+			if info.Insulin == nil {
+				t.Fatal("Insulin is nil")
+			}
+			if got, want := float64(*info.Insulin), float64(test.record.Amount)/1000; got != want {
+				t.Errorf("Insulin = %v, want %v", got, want)
+			}
+
+			if test.record.Duration == 0 {
+				if info.SplitNow != nil || info.SplitExt != nil || info.Relative != nil {
+					t.Errorf("plain bolus should not set combo-bolus fields")
+				}
+				return
+			}
+			if info.SplitNow == nil || *info.SplitNow != 0 {
+				t.Errorf("SplitNow = %v, want 0", info.SplitNow)
+			}
+			if info.SplitExt == nil || *info.SplitExt != 100 {
+				t.Errorf("SplitExt = %v, want 100", info.SplitExt)
+			}
+			wantRelative := float64(*info.Insulin) / (float64(test.wantDuration) / 60)
+			if info.Relative == nil || *info.Relative != wantRelative {
+				t.Errorf("Relative = %v, want %v", info.Relative, wantRelative)
+			}
+		})
+	}
+}
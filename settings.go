@@ -1,13 +1,50 @@
 package medtronic
 
 import (
+	"fmt"
+	"log"
 	"time"
 )
 
 const (
 	Settings Command = 0xC0
+
+	setAutoOff       Command = 0x1A
+	setMaxBolus      Command = 0x31
+	setMaxBasal      Command = 0x32
+	setInsulinAction Command = 0x34
+
+	minInsulinAction = 2 * time.Hour
+	maxInsulinAction = 8 * time.Hour
+	maxAutoOff       = 24 * time.Hour
+	maxBolusStep     = 100   // milliUnits (0.1 U)
+	maxMaxBolus      = 25000 // milliUnits; sent as a single byte of maxBolusStep units (250)
+)
+
+// AlarmType represents the pump's configured alarm style.
+type AlarmType byte
+
+//go:generate stringer -type AlarmType
+
+// Alarm types.
+const (
+	BeepAlarm    AlarmType = 0
+	VibrateAlarm AlarmType = 1
+)
+
+// settingsOption is a bit within the options byte of the extended
+// settings payload (family >= 23 only).
+type settingsOption byte
+
+const (
+	bolusReminderOption settingsOption = 1 << 0
+	sensorOption        settingsOption = 1 << 1
+	bgReminderOption    settingsOption = 1 << 2
+	blockModeOption     settingsOption = 1 << 3
+	keypadLockOption    settingsOption = 1 << 4
 )
 
+// SettingsInfo represents the pump's configurable settings.
 type SettingsInfo struct {
 	AutoOff              time.Duration
 	InsulinAction        time.Duration
@@ -16,6 +53,48 @@ type SettingsInfo struct {
 	MaxBasal             MilliUnits
 	RfEnabled            bool
 	SelectedPattern      int
+
+	// The following fields that have a flag in PumpFamilyCapabilities
+	// are only meaningful for the families it reports; see there.
+	// BolusStep, LowReservoirWarning, AlarmType and TempBasalType
+	// are decoded for all families.
+	BolusStep            MilliUnits
+	BasalStep            MilliUnits
+	LowReservoirWarning  MilliUnits
+	AlarmType            AlarmType
+	BolusReminderEnabled bool
+	SensorEnabled        bool
+	BGReminderEnabled    bool
+	BlockModeEnabled     bool
+	KeypadLockEnabled    bool
+	TempBasalType        TempBasalType
+}
+
+// PumpFamilyCapabilities reports which of the extended SettingsInfo
+// fields are populated for a given pump family. Older (pre-23)
+// families use a shorter settings payload that omits all of them
+// except KeypadLockEnabled.
+type PumpFamilyCapabilities struct {
+	BasalStep            bool
+	BolusReminderEnabled bool
+	SensorEnabled        bool
+	BGReminderEnabled    bool
+	BlockModeEnabled     bool
+	KeypadLockEnabled    bool
+}
+
+// Capabilities reports which extended settings fields the pump's
+// family populates.
+func (pump *Pump) Capabilities() PumpFamilyCapabilities {
+	newer := pump.Family() >= 23
+	return PumpFamilyCapabilities{
+		BasalStep:            newer,
+		BolusReminderEnabled: newer,
+		SensorEnabled:        newer,
+		BGReminderEnabled:    newer,
+		BlockModeEnabled:     newer,
+		KeypadLockEnabled:    true,
+	}
 }
 
 func (pump *Pump) Settings() SettingsInfo {
@@ -25,23 +104,34 @@ func (pump *Pump) Settings() SettingsInfo {
 	if pump.Error() != nil {
 		return SettingsInfo{}
 	}
+	info, err := decodeSettings(data, newer)
+	pump.SetError(err)
+	return info
+}
+
+// decodeSettings decodes the response to a Settings command. newer
+// selects the longer payload format used by family >= 23 pumps
+// (523/723/554/754 and later), which adds BasalStep and the options
+// byte of boolean fields.
+func decodeSettings(data []byte, newer bool) (SettingsInfo, error) {
 	if newer {
 		if len(data) < 26 || data[0] != 25 {
-			pump.BadResponse(Settings, data)
-			return SettingsInfo{}
+			return SettingsInfo{}, BadResponseError{Command: Settings, Data: data}
 		}
 	} else {
 		if len(data) < 22 || data[0] != 21 {
-			pump.BadResponse(Settings, data)
-			return SettingsInfo{}
+			return SettingsInfo{}, BadResponseError{Command: Settings, Data: data}
 		}
 	}
 	info := SettingsInfo{
-		AutoOff:         time.Duration(data[1]) * time.Hour,
-		MaxBolus:        byteToMilliUnits(data[6], false),
-		SelectedPattern: int(data[12]),
-		RfEnabled:       data[13] == 1,
-		InsulinAction:   time.Duration(data[18]) * time.Hour,
+		AutoOff:             time.Duration(data[1]) * time.Hour,
+		BolusStep:           byteToMilliUnits(data[2], newer),
+		LowReservoirWarning: MilliUnits(data[4]) * 100,
+		AlarmType:           AlarmType(data[5]),
+		MaxBolus:            byteToMilliUnits(data[6], false),
+		SelectedPattern:     int(data[12]),
+		RfEnabled:           data[13] == 1,
+		InsulinAction:       time.Duration(data[18]) * time.Hour,
 	}
 	switch data[10] {
 	case 0:
@@ -49,12 +139,86 @@ func (pump *Pump) Settings() SettingsInfo {
 	case 1:
 		info.InsulinConcentration = 50
 	default:
-		pump.BadResponse(Settings, data)
+		return info, BadResponseError{Command: Settings, Data: data}
 	}
 	if newer {
 		info.MaxBasal = twoByteMilliUnits(data[8:10], true)
+		info.BasalStep = byteToMilliUnits(data[3], true)
+		options := settingsOption(data[14])
+		info.BolusReminderEnabled = options&bolusReminderOption != 0
+		info.SensorEnabled = options&sensorOption != 0
+		info.BGReminderEnabled = options&bgReminderOption != 0
+		info.BlockModeEnabled = options&blockModeOption != 0
+		info.KeypadLockEnabled = options&keypadLockOption != 0
 	} else {
 		info.MaxBasal = twoByteMilliUnits(data[7:9], false)
+		info.KeypadLockEnabled = data[14] != 0
 	}
-	return info
+	info.TempBasalType = TempBasalType(data[17])
+	return info, nil
+}
+
+// SetAutoOff sets the pump's auto-off timer, after which the pump
+// will alarm and eventually suspend if left untouched.
+func (pump *Pump) SetAutoOff(d time.Duration) {
+	if d < 0 || d%time.Hour != 0 {
+		pump.SetError(fmt.Errorf("auto-off duration (%v) is not a non-negative multiple of 1 hour", d))
+		return
+	}
+	if d > maxAutoOff {
+		pump.SetError(fmt.Errorf("auto-off duration (%v) is too large", d))
+		return
+	}
+	pump.Execute(setAutoOff, byte(d/time.Hour))
+}
+
+// SetInsulinAction sets the pump's insulin action duration, used to
+// calculate insulin-on-board.
+func (pump *Pump) SetInsulinAction(d time.Duration) {
+	if d%time.Hour != 0 {
+		pump.SetError(fmt.Errorf("insulin action duration (%v) is not a multiple of 1 hour", d))
+		return
+	}
+	if d < minInsulinAction || d > maxInsulinAction {
+		pump.SetError(fmt.Errorf("insulin action duration (%v) is out of range", d))
+		return
+	}
+	pump.Execute(setInsulinAction, byte(d/time.Hour))
+}
+
+// SetMaxBolus sets the pump's maximum allowed bolus amount.
+func (pump *Pump) SetMaxBolus(max MilliUnits) {
+	if max < 0 {
+		pump.SetError(fmt.Errorf("maximum bolus (%v) is negative", max))
+		return
+	}
+	if max > maxMaxBolus {
+		pump.SetError(fmt.Errorf("maximum bolus (%v) is too large", max))
+		return
+	}
+	strokes := max / maxBolusStep
+	actual := strokes * maxBolusStep
+	if actual != max {
+		log.Printf("rounding maximum bolus from %v to %v", max, actual)
+	}
+	pump.Execute(setMaxBolus, byte(strokes))
+}
+
+// SetMaxBasal sets the pump's maximum allowed basal rate.
+func (pump *Pump) SetMaxBasal(max MilliUnits) {
+	if max < 0 {
+		pump.SetError(fmt.Errorf("maximum basal rate (%v) is negative", max))
+		return
+	}
+	if max > maxBasal {
+		pump.SetError(fmt.Errorf("maximum basal rate (%v) is too large", max))
+		return
+	}
+	strokes := max / 25
+	actual := strokes * 25
+	if actual != max {
+		log.Printf("rounding maximum basal rate from %v to %v", max, actual)
+	}
+	r := marshalUint16(uint16(strokes))
+	pump.Execute(setMaxBasal, r[0], r[1])
 }
@@ -0,0 +1,127 @@
+package medtronic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeSettings(t *testing.T) {
+	// Byte offsets, for both fixtures below:
+	//   0: byte count          1: AutoOff (hours)     2: BolusStep
+	//   3: BasalStep (newer)   4: LowReservoirWarning  5: AlarmType
+	//   6: MaxBolus            7-8 or 8-9: MaxBasal    10: InsulinConcentration
+	//   12: SelectedPattern    13: RfEnabled           14: KeypadLock bit (older) or options byte (newer)
+	//   17: TempBasalType      18: InsulinAction (hours)
+	older := make([]byte, 22)
+	older[0] = 21
+	older[1] = 7
+	older[2] = 2
+	older[4] = 4
+	older[5] = 1
+	older[6] = 50
+	older[7] = 0
+	older[8] = 40
+	older[10] = 1
+	older[12] = 2
+	older[13] = 1
+	older[14] = 1
+	older[17] = 1
+	older[18] = 4
+
+	newer := make([]byte, 26)
+	newer[0] = 25
+	newer[1] = 7
+	newer[2] = 2
+	newer[3] = 3
+	newer[4] = 4
+	newer[5] = 1
+	newer[6] = 50
+	newer[8] = 0
+	newer[9] = 40
+	newer[10] = 1
+	newer[12] = 2
+	newer[13] = 1
+	newer[14] = byte(bolusReminderOption | bgReminderOption | keypadLockOption)
+	newer[17] = 1
+	newer[18] = 4
+
+	tests := []struct {
+		name  string
+		data  []byte
+		newer bool
+		want  SettingsInfo
+	}{
+		{
+			name:  "older family (< 23)",
+			data:  older,
+			newer: false,
+			want: SettingsInfo{
+				AutoOff:              7 * time.Hour,
+				BolusStep:            byteToMilliUnits(2, false),
+				LowReservoirWarning:  400,
+				AlarmType:            VibrateAlarm,
+				MaxBolus:             byteToMilliUnits(50, false),
+				MaxBasal:             twoByteMilliUnits(older[7:9], false),
+				InsulinConcentration: 50,
+				SelectedPattern:      2,
+				RfEnabled:            true,
+				KeypadLockEnabled:    true,
+				TempBasalType:        Percent,
+				InsulinAction:        4 * time.Hour,
+			},
+		},
+		{
+			name:  "newer family (>= 23)",
+			data:  newer,
+			newer: true,
+			want: SettingsInfo{
+				AutoOff:              7 * time.Hour,
+				BolusStep:            byteToMilliUnits(2, true),
+				BasalStep:            byteToMilliUnits(3, true),
+				LowReservoirWarning:  400,
+				AlarmType:            VibrateAlarm,
+				MaxBolus:             byteToMilliUnits(50, false),
+				MaxBasal:             twoByteMilliUnits(newer[8:10], true),
+				InsulinConcentration: 50,
+				SelectedPattern:      2,
+				RfEnabled:            true,
+				BolusReminderEnabled: true,
+				BGReminderEnabled:    true,
+				KeypadLockEnabled:    true,
+				TempBasalType:        Percent,
+				InsulinAction:        4 * time.Hour,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := decodeSettings(test.data, test.newer)
+			if err != nil {
+				t.Fatalf("decodeSettings(% X, %v) returned error %v", test.data, test.newer, err)
+			}
+			if got != test.want {
+				t.Errorf("decodeSettings(% X, %v) = %+v, want %+v", test.data, test.newer, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeSettingsBadResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		newer bool
+	}{
+		{"older too short", make([]byte, 10), false},
+		{"newer too short", make([]byte, 10), true},
+		{"older wrong count byte", append([]byte{0}, make([]byte, 21)...), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeSettings(test.data, test.newer)
+			if err == nil {
+				t.Errorf("decodeSettings(% X, %v) returned nil error, want BadResponseError", test.data, test.newer)
+			}
+		})
+	}
+}
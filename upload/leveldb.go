@@ -0,0 +1,55 @@
+package upload
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// levelDBStore is the default store, an embedded key/value database
+// that survives process restarts without requiring any external
+// service.
+type levelDBStore struct {
+	db *leveldb.DB
+}
+
+func openLevelDBStore(path string) (store, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBStore{db: db}, nil
+}
+
+func (s *levelDBStore) get(key string) ([]byte, bool, error) {
+	value, err := s.db.Get([]byte(key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (s *levelDBStore) put(key string, value []byte) error {
+	return s.db.Put([]byte(key), value, nil)
+}
+
+func (s *levelDBStore) delete(key string) error {
+	return s.db.Delete([]byte(key), nil)
+}
+
+func (s *levelDBStore) forEach(f func(key string, value []byte) error) error {
+	iter := s.db.NewIterator(util.BytesPrefix(nil), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if err := f(string(iter.Key()), append([]byte(nil), iter.Value()...)); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (s *levelDBStore) close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,173 @@
+// Package upload provides a durable queue for Nightscout treatment
+// uploads, so that treatments produced while the Nightscout endpoint
+// is unreachable (for example during an overnight radio outage) are
+// retried instead of lost.
+package upload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/ecc1/nightscout"
+)
+
+const (
+	pollInterval   = 30 * time.Second
+	initialBackoff = time.Minute
+	maxBackoff     = 2 * time.Hour
+)
+
+// Uploader posts treatments to Nightscout. It is satisfied by
+// *nightscout.Client.
+type Uploader interface {
+	PostTreatments(treatments []nightscout.Treatment) error
+}
+
+// Queue is a durable, backend-agnostic queue of pending Nightscout
+// treatment uploads.
+type Queue struct {
+	backend store
+}
+
+// entry is the JSON-encoded value stored for each queued treatment.
+type entry struct {
+	Treatment   nightscout.Treatment
+	Attempts    int
+	NextAttempt time.Time
+}
+
+// NewQueue opens the queue backed by the store named by uri. A
+// leveldb: or file: URI (or a bare path, for convenience) opens an
+// embedded LevelDB database; a redis: URI connects to a Redis server.
+func NewQueue(uri string) (*Queue, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("upload: invalid queue URI %q: %v", uri, err)
+	}
+	var s store
+	switch u.Scheme {
+	case "", "file", "leveldb":
+		path := uri
+		if u.Scheme != "" {
+			path = u.Opaque
+			if path == "" {
+				path = u.Path
+			}
+		}
+		s, err = openLevelDBStore(path)
+	case "redis":
+		s, err = openRedisStore(uri)
+	default:
+		return nil, fmt.Errorf("upload: unsupported queue URI scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Queue{backend: s}, nil
+}
+
+// Close releases the queue's underlying storage.
+func (q *Queue) Close() error {
+	return q.backend.close()
+}
+
+// Enqueue adds treatments to the queue for later upload. The key for
+// each treatment is its CreatedAt timestamp plus a stable hash of its
+// contents, so re-enqueuing the same treatment (as happens when a
+// pump is re-read after an outage) is idempotent: if the treatment is
+// already queued, its existing Attempts/NextAttempt backoff state is
+// left untouched rather than reset.
+func (q *Queue) Enqueue(treatments []nightscout.Treatment) error {
+	for _, t := range treatments {
+		key := entryKey(t)
+		if _, found, err := q.backend.get(key); err != nil {
+			return err
+		} else if found {
+			continue
+		}
+		data, err := json.Marshal(entry{Treatment: t})
+		if err != nil {
+			return err
+		}
+		if err := q.backend.put(key, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Drain repeatedly uploads queued treatments that are due for
+// retry via client, until ctx is done. Treatments that upload
+// successfully are removed from the queue; those that fail are kept
+// and retried with exponential backoff.
+func (q *Queue) Drain(ctx context.Context, client Uploader) error {
+	for {
+		if err := q.drainOnce(client); err != nil {
+			log.Printf("upload: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (q *Queue) drainOnce(client Uploader) error {
+	now := time.Now()
+	return q.backend.forEach(func(key string, data []byte) error {
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			log.Printf("upload: dropping malformed queue entry %s: %v", key, err)
+			return q.backend.delete(key)
+		}
+		if now.Before(e.NextAttempt) {
+			return nil
+		}
+		err := client.PostTreatments([]nightscout.Treatment{e.Treatment})
+		if err == nil {
+			return q.backend.delete(key)
+		}
+		e.Attempts++
+		e.NextAttempt = now.Add(backoff(e.Attempts))
+		log.Printf("upload: retry %d for %s failed: %v", e.Attempts, key, err)
+		data, marshalErr := json.Marshal(e)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		return q.backend.put(key, data)
+	})
+}
+
+// backoff returns the delay before the next retry, doubling with
+// each attempt up to maxBackoff.
+func backoff(attempts int) time.Duration {
+	d := initialBackoff << uint(attempts-1)
+	if d > maxBackoff || d <= 0 {
+		return maxBackoff
+	}
+	return d
+}
+
+// entryKey derives a stable, idempotent queue key for a treatment
+// from its creation time and a hash of its contents.
+func entryKey(t nightscout.Treatment) string {
+	data, _ := json.Marshal(t)
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s-%x", t.CreatedAt.Format(time.RFC3339Nano), sum[:8])
+}
+
+// store is the persistence interface implemented by each pluggable
+// queue backend.
+type store interface {
+	get(key string) (value []byte, found bool, err error)
+	put(key string, value []byte) error
+	delete(key string) error
+	forEach(func(key string, value []byte) error) error
+	close() error
+}
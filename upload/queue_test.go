@@ -0,0 +1,135 @@
+package upload
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ecc1/nightscout"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, initialBackoff},
+		{2, 2 * initialBackoff},
+		{3, 4 * initialBackoff},
+		{10, maxBackoff}, // doubling would exceed the cap
+	}
+	for _, test := range tests {
+		if got := backoff(test.attempts); got != test.want {
+			t.Errorf("backoff(%d) = %v, want %v", test.attempts, got, test.want)
+		}
+	}
+}
+
+// memStore is a minimal in-memory store implementation, used to test
+// Queue behavior that depends on the store interface without a real
+// LevelDB or Redis backend.
+type memStore struct {
+	data map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{data: make(map[string][]byte)}
+}
+
+func (s *memStore) get(key string) ([]byte, bool, error) {
+	value, found := s.data[key]
+	return value, found, nil
+}
+
+func (s *memStore) put(key string, value []byte) error {
+	s.data[key] = value
+	return nil
+}
+
+func (s *memStore) delete(key string) error {
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memStore) forEach(f func(key string, value []byte) error) error {
+	for key, value := range s.data {
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memStore) close() error {
+	return nil
+}
+
+func TestEnqueuePreservesBackoff(t *testing.T) {
+	backend := newMemStore()
+	q := &Queue{backend: backend}
+
+	treatment := nightscout.Treatment{
+		CreatedAt: time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC),
+		EventType: "Meal Bolus",
+	}
+	if err := q.Enqueue([]nightscout.Treatment{treatment}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	key := entryKey(treatment)
+	data, found, err := backend.get(key)
+	if err != nil || !found {
+		t.Fatalf("get(%q) = %v, %v, %v", key, data, found, err)
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	e.Attempts = 5
+	e.NextAttempt = time.Date(2026, 7, 29, 14, 0, 0, 0, time.UTC)
+	data, err = json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := backend.put(key, data); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	// Re-enqueuing the same treatment, as happens when a periodic
+	// history poll re-reads an unsent window, must not reset the
+	// backoff state set by a prior failed drainOnce.
+	if err := q.Enqueue([]nightscout.Treatment{treatment}); err != nil {
+		t.Fatalf("Enqueue (re-enqueue): %v", err)
+	}
+	data, found, err = backend.get(key)
+	if err != nil || !found {
+		t.Fatalf("get(%q) = %v, %v, %v", key, data, found, err)
+	}
+	var got entry
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Attempts != e.Attempts || !got.NextAttempt.Equal(e.NextAttempt) {
+		t.Errorf("re-enqueue reset backoff state: got %+v, want Attempts=%d NextAttempt=%v", got, e.Attempts, e.NextAttempt)
+	}
+}
+
+func TestEntryKey(t *testing.T) {
+	t1 := nightscout.Treatment{
+		CreatedAt: time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC),
+		EventType: "Meal Bolus",
+	}
+	t2 := t1
+	t2.EventType = "Combo Bolus"
+
+	k1a := entryKey(t1)
+	k1b := entryKey(t1)
+	if k1a != k1b {
+		t.Errorf("entryKey(%+v) is not stable: %q != %q", t1, k1a, k1b)
+	}
+
+	k2 := entryKey(t2)
+	if k1a == k2 {
+		t.Errorf("entryKey collided for distinct treatments sharing a CreatedAt: %q", k1a)
+	}
+}
@@ -0,0 +1,66 @@
+package upload
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisQueueKey is the Redis hash that holds all queued entries,
+// keyed by the same entryKey used by the LevelDB backend.
+const redisQueueKey = "medtronic:upload:queue"
+
+// redisStore is the pluggable backend for deployments that already
+// run a shared Redis instance, configured by passing a redis: URI to
+// NewQueue.
+type redisStore struct {
+	client *redis.Client
+}
+
+func openRedisStore(uri string) (store, error) {
+	opts, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+	return &redisStore{client: client}, nil
+}
+
+func (s *redisStore) get(key string) ([]byte, bool, error) {
+	value, err := s.client.HGet(context.Background(), redisQueueKey, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (s *redisStore) put(key string, value []byte) error {
+	return s.client.HSet(context.Background(), redisQueueKey, key, value).Err()
+}
+
+func (s *redisStore) delete(key string) error {
+	return s.client.HDel(context.Background(), redisQueueKey, key).Err()
+}
+
+func (s *redisStore) forEach(f func(key string, value []byte) error) error {
+	entries, err := s.client.HGetAll(context.Background(), redisQueueKey).Result()
+	if err != nil {
+		return err
+	}
+	for key, value := range entries {
+		if err := f(key, []byte(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *redisStore) close() error {
+	return s.client.Close()
+}